@@ -0,0 +1,287 @@
+// Package config loads restaurant simulation configuration - the menu,
+// kitchen sizing, and an optional workload script - from a JSON or YAML
+// file, so the simulator can be driven without recompiling.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// Config is the root of a restaurant configuration file.
+type Config struct {
+	Restaurant RestaurantConfig     `yaml:"restaurant" json:"restaurant"`
+	Menu       map[string]MenuEntry `yaml:"menu" json:"menu"`
+	Inventory  InventoryConfig      `yaml:"inventory" json:"inventory"`
+	Workload   []WorkloadEntry      `yaml:"workload" json:"workload"`
+}
+
+// RestaurantConfig sets the kitchen's sizing and display name. Stations
+// maps a station name (e.g. "grill") to the number of workers staffing it.
+type RestaurantConfig struct {
+	Name        string         `yaml:"name" json:"name"`
+	MaxCapacity int            `yaml:"max_capacity" json:"max_capacity"`
+	Stations    map[string]int `yaml:"stations" json:"stations"`
+	// OrderPatience is how long an order may wait before it's
+	// automatically cancelled. Zero disables the timeout.
+	OrderPatience Duration `yaml:"order_patience" json:"order_patience"`
+}
+
+// InventoryConfig seeds ingredient stock and controls the background
+// restocker. RestockInterval of zero disables the restocker.
+type InventoryConfig struct {
+	InitialStock     map[string]int `yaml:"initial_stock" json:"initial_stock"`
+	RestockInterval  Duration       `yaml:"restock_interval" json:"restock_interval"`
+	RestockBatchSize int            `yaml:"restock_batch_size" json:"restock_batch_size"`
+}
+
+// MenuEntry describes a single menu item. It may be written in a config
+// file either as a shorthand string, which is resolved against
+// DefaultCatalog by the entry's own key (so price, prep time, ingredients,
+// and stations all come from the catalog item; the string itself only
+// overrides the display name), or as a full object with name, price,
+// prep_time, and optional ingredients.
+type MenuEntry struct {
+	Name        string          `yaml:"name" json:"name"`
+	Price       float64         `yaml:"price" json:"price"`
+	PrepTime    Duration        `yaml:"prep_time" json:"prep_time"`
+	Ingredients []IngredientUse `yaml:"ingredients,omitempty" json:"ingredients,omitempty"`
+	Stations    []string        `yaml:"stations,omitempty" json:"stations,omitempty"`
+
+	// shorthand records whether this entry was written as a bare string,
+	// so MenuItems knows to resolve it against DefaultCatalog instead of
+	// taking Price/PrepTime/Ingredients/Stations at their zero values.
+	shorthand bool
+}
+
+// DefaultCatalog is the set of menu items a shorthand config entry
+// resolves against, keyed the same way as Config.Menu. It's also the menu
+// the simulator runs with when no --config file is given.
+var DefaultCatalog = map[string]restaurant.MenuItem{
+	"burger": {
+		Name:     "Classic Burger",
+		Price:    12.99,
+		PrepTime: 8 * time.Minute,
+		Stations: []restaurant.Station{restaurant.StationGrill},
+	},
+	"pizza": {
+		Name:     "Margherita Pizza",
+		Price:    15.99,
+		PrepTime: 12 * time.Minute,
+		Stations: []restaurant.Station{restaurant.StationOven},
+	},
+	"pasta": {
+		Name:     "Spaghetti Carbonara",
+		Price:    14.99,
+		PrepTime: 10 * time.Minute,
+	},
+	"salad": {
+		Name:     "Caesar Salad",
+		Price:    9.99,
+		PrepTime: 5 * time.Minute,
+		Stations: []restaurant.Station{restaurant.StationSalad},
+	},
+	"soup": {
+		Name:     "Tomato Soup",
+		Price:    6.99,
+		PrepTime: 3 * time.Minute,
+	},
+	"fries": {
+		Name:     "French Fries",
+		Price:    4.99,
+		PrepTime: 4 * time.Minute,
+		Stations: []restaurant.Station{restaurant.StationFryer},
+	},
+}
+
+// IngredientUse describes how much of an ingredient a menu item consumes.
+// It may be written as a shorthand string, taken to mean one unit of that
+// ingredient, or as a full object with ingredient and quantity.
+type IngredientUse struct {
+	Ingredient string `yaml:"ingredient" json:"ingredient"`
+	Quantity   int    `yaml:"quantity" json:"quantity"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a bare string
+// shorthand (one unit) or a full object.
+func (u *IngredientUse) UnmarshalJSON(data []byte) error {
+	var shorthand string
+	if err := json.Unmarshal(data, &shorthand); err == nil {
+		u.Ingredient = shorthand
+		u.Quantity = 1
+		return nil
+	}
+
+	type alias IngredientUse
+	var full alias
+	if err := json.Unmarshal(data, &full); err != nil {
+		return fmt.Errorf("ingredient use must be a string or an object: %w", err)
+	}
+	*u = IngredientUse(full)
+	if u.Quantity == 0 {
+		u.Quantity = 1
+	}
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting either a bare string
+// shorthand (one unit) or a full object.
+func (u *IngredientUse) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var shorthand string
+		if err := value.Decode(&shorthand); err != nil {
+			return err
+		}
+		u.Ingredient = shorthand
+		u.Quantity = 1
+		return nil
+	}
+
+	type alias IngredientUse
+	var full alias
+	if err := value.Decode(&full); err != nil {
+		return fmt.Errorf("ingredient use must be a string or an object: %w", err)
+	}
+	*u = IngredientUse(full)
+	if u.Quantity == 0 {
+		u.Quantity = 1
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a bare string
+// shorthand or a full object.
+func (m *MenuEntry) UnmarshalJSON(data []byte) error {
+	var shorthand string
+	if err := json.Unmarshal(data, &shorthand); err == nil {
+		m.Name = shorthand
+		m.shorthand = true
+		return nil
+	}
+
+	type alias MenuEntry
+	var full alias
+	if err := json.Unmarshal(data, &full); err != nil {
+		return fmt.Errorf("menu entry must be a string or an object: %w", err)
+	}
+	*m = MenuEntry(full)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting either a bare string
+// shorthand or a full object.
+func (m *MenuEntry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var shorthand string
+		if err := value.Decode(&shorthand); err != nil {
+			return err
+		}
+		m.Name = shorthand
+		m.shorthand = true
+		return nil
+	}
+
+	type alias MenuEntry
+	var full alias
+	if err := value.Decode(&full); err != nil {
+		return fmt.Errorf("menu entry must be a string or an object: %w", err)
+	}
+	*m = MenuEntry(full)
+	return nil
+}
+
+// WorkloadEntry schedules a single customer order to be created
+// ArrivalOffset after the simulation starts.
+type WorkloadEntry struct {
+	Customer      string   `yaml:"customer" json:"customer"`
+	Items         []string `yaml:"items" json:"items"`
+	ArrivalOffset Duration `yaml:"arrival_offset" json:"arrival_offset"`
+}
+
+// Load reads and parses a restaurant config from path. The format is
+// chosen by file extension: .yaml/.yml or .json.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing yaml config %s: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing json config %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config extension %q (want .yaml, .yml, or .json)", ext)
+	}
+
+	return &cfg, nil
+}
+
+// MenuItems converts the config's menu into the map of restaurant.MenuItem
+// keyed by the short name used in orders and workload entries. A shorthand
+// entry is resolved against DefaultCatalog by that same key; it's an error
+// if the catalog has no matching item, since there would otherwise be
+// nowhere to take its price, prep time, ingredients, or stations from.
+func (c *Config) MenuItems() (map[string]restaurant.MenuItem, error) {
+	items := make(map[string]restaurant.MenuItem, len(c.Menu))
+	for key, entry := range c.Menu {
+		if entry.shorthand {
+			catalogItem, ok := DefaultCatalog[key]
+			if !ok {
+				return nil, fmt.Errorf("menu item %q: shorthand %q has no matching entry in the default catalog; use a full object with price and prep_time", key, entry.Name)
+			}
+			if entry.Name != "" {
+				catalogItem.Name = entry.Name
+			}
+			items[key] = catalogItem
+			continue
+		}
+
+		name := entry.Name
+		if name == "" {
+			name = key
+		}
+		ingredients := make([]restaurant.IngredientUse, len(entry.Ingredients))
+		for i, use := range entry.Ingredients {
+			ingredients[i] = restaurant.IngredientUse{Ingredient: use.Ingredient, Quantity: use.Quantity}
+		}
+
+		stations := make([]restaurant.Station, len(entry.Stations))
+		for i, s := range entry.Stations {
+			stations[i] = restaurant.Station(s)
+		}
+
+		items[key] = restaurant.MenuItem{
+			Name:        name,
+			Price:       entry.Price,
+			PrepTime:    time.Duration(entry.PrepTime),
+			Ingredients: ingredients,
+			Stations:    stations,
+		}
+	}
+	return items, nil
+}
+
+// StationWorkers converts the config's station worker counts into the map
+// kitchen.NewKitchen expects.
+func (c *Config) StationWorkers() map[restaurant.Station]int {
+	workers := make(map[restaurant.Station]int, len(c.Restaurant.Stations))
+	for name, count := range c.Restaurant.Stations {
+		workers[restaurant.Station(name)] = count
+	}
+	return workers
+}
@@ -0,0 +1,45 @@
+// Package delivery handles delivering orders once the kitchen marks them
+// ready.
+package delivery
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// Notifier receives order status change notifications as orders are
+// delivered.
+type Notifier interface {
+	Publish(order *restaurant.Order, status restaurant.OrderStatus)
+}
+
+// Run delivers orders as they arrive on readyOrders, simulating delivery
+// time against clock. rng drives the random delivery duration, so a seeded
+// *rand.Rand makes delivery times reproducible across runs. Run returns
+// once readyOrders is closed and drained.
+func Run(restaurantID int, clock restaurant.Clock, rng *rand.Rand, readyOrders <-chan *restaurant.Order, notifier Notifier) {
+	for order := range readyOrders {
+		deliveryTime := time.Duration(rng.Intn(5)+1) * time.Second
+		err := restaurant.SimulateDelay(order.Ctx, clock, deliveryTime, fmt.Sprintf("Delivering order #%d to %s", order.ID, order.CustomerName))
+
+		if err != nil {
+			order.SetStatus(restaurant.OrderCancelled)
+			if notifier != nil {
+				notifier.Publish(order, restaurant.OrderCancelled)
+			}
+			slog.Info("delivery: order cancelled while out for delivery", "restaurant_id", restaurantID, "order_id", order.ID, "customer", order.CustomerName)
+			continue
+		}
+
+		order.MarkDelivered(clock.Now())
+		if notifier != nil {
+			notifier.Publish(order, restaurant.OrderDelivered)
+		}
+
+		slog.Info("delivery: order delivered", "restaurant_id", restaurantID, "order_id", order.ID, "customer", order.CustomerName, "delivery_time", deliveryTime)
+	}
+}
@@ -0,0 +1,153 @@
+package kitchen
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ogradyo/restaurant-simulator/metrics"
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// Inventory tracks per-ingredient stock levels available to the kitchen. A
+// nil *Inventory on Kitchen means ingredients aren't tracked and every
+// order can always be prepared.
+type Inventory struct {
+	// Metrics records stock levels as they change. Nil means metrics
+	// aren't tracked.
+	Metrics *metrics.Registry
+	// OnRestock is called after stock changes via Restock or the
+	// restocker, regardless of which ingredient or how much. Kitchen uses
+	// it to retry orders that were backordered for lack of stock. Nil
+	// means nobody is watching for restocks.
+	OnRestock func()
+
+	mu    sync.Mutex
+	stock map[string]int
+}
+
+// NewInventory creates an Inventory seeded with the given starting stock
+// levels.
+func NewInventory(initial map[string]int) *Inventory {
+	stock := make(map[string]int, len(initial))
+	for ingredient, qty := range initial {
+		stock[ingredient] = qty
+	}
+	return &Inventory{stock: stock}
+}
+
+// TryReserve atomically decrements stock for every ingredient used,
+// reserving all of them or none. It reports whether the reservation
+// succeeded.
+func (inv *Inventory) TryReserve(uses []restaurant.IngredientUse) bool {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	for _, use := range uses {
+		if inv.stock[use.Ingredient] < use.Quantity {
+			return false
+		}
+	}
+	for _, use := range uses {
+		inv.stock[use.Ingredient] -= use.Quantity
+	}
+	inv.reportLevelsLocked()
+	return true
+}
+
+// Restock adds amount units of ingredient to stock.
+func (inv *Inventory) Restock(ingredient string, amount int) {
+	inv.mu.Lock()
+	inv.stock[ingredient] += amount
+	inv.reportLevelsLocked()
+	inv.mu.Unlock()
+	inv.notifyRestock()
+}
+
+// Levels returns a snapshot of current stock levels.
+func (inv *Inventory) Levels() map[string]int {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	levels := make(map[string]int, len(inv.stock))
+	for ingredient, qty := range inv.stock {
+		levels[ingredient] = qty
+	}
+	return levels
+}
+
+// RunRestocker replenishes every tracked ingredient by batchSize once per
+// interval of real wall-clock time, until stop is closed. It paces itself
+// with its own time.Ticker rather than the simulation's Clock: the
+// restocker is a background process of the service, running on its own
+// cadence independent of the simulated order timeline, and in fast-forward
+// mode a VirtualClock only advances when something simulating a delay
+// chooses to advance it. Pacing the restocker against that same clock
+// would make every order's CreatedAt/ReadyAt depend on how fast this loop
+// happened to be scheduled relative to the rest of the simulation, instead
+// of on simulated order events.
+func (inv *Inventory) RunRestocker(interval time.Duration, batchSize int, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			inv.restockAll(batchSize)
+			slog.Info("inventory restocked", "batch_size", batchSize)
+		}
+	}
+}
+
+func (inv *Inventory) restockAll(batchSize int) {
+	inv.mu.Lock()
+	for ingredient := range inv.stock {
+		inv.stock[ingredient] += batchSize
+	}
+	inv.reportLevelsLocked()
+	inv.mu.Unlock()
+	inv.notifyRestock()
+}
+
+// notifyRestock calls OnRestock, if configured. Callers must not hold
+// inv.mu, since OnRestock may itself call back into Inventory (e.g. to
+// retry a reservation).
+func (inv *Inventory) notifyRestock() {
+	if inv.OnRestock != nil {
+		inv.OnRestock()
+	}
+}
+
+// reportLevelsLocked pushes the current stock levels to Metrics, if
+// configured. Callers must hold inv.mu.
+func (inv *Inventory) reportLevelsLocked() {
+	if inv.Metrics == nil {
+		return
+	}
+	for ingredient, qty := range inv.stock {
+		inv.Metrics.SetInventoryLevel(ingredient, qty)
+	}
+}
+
+// aggregateIngredientUse sums ingredient quantities needed across every
+// item in an order.
+func aggregateIngredientUse(items []restaurant.MenuItem) []restaurant.IngredientUse {
+	totals := make(map[string]int)
+	var order []string
+	for _, item := range items {
+		for _, use := range item.Ingredients {
+			if _, seen := totals[use.Ingredient]; !seen {
+				order = append(order, use.Ingredient)
+			}
+			totals[use.Ingredient] += use.Quantity
+		}
+	}
+
+	uses := make([]restaurant.IngredientUse, 0, len(order))
+	for _, ingredient := range order {
+		uses = append(uses, restaurant.IngredientUse{Ingredient: ingredient, Quantity: totals[ingredient]})
+	}
+	return uses
+}
@@ -0,0 +1,314 @@
+// Package kitchen models the restaurant's kitchen: order intake, capacity
+// limits, and preparation across per-station worker pools.
+package kitchen
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ogradyo/restaurant-simulator/metrics"
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// Notifier receives order status change notifications as the kitchen moves
+// orders through preparation.
+type Notifier interface {
+	Publish(order *restaurant.Order, status restaurant.OrderStatus)
+}
+
+// Kitchen represents the restaurant kitchen
+type Kitchen struct {
+	RestaurantID int
+	Mode         restaurant.SimulationMode
+	Clock        restaurant.Clock
+	MaxCapacity  int
+	CurrentLoad  int
+	OrderQueue   chan *restaurant.Order
+	ReadyOrders  chan *restaurant.Order
+	Notifier     Notifier
+	Inventory    *Inventory
+	Stations     map[restaurant.Station]*StationPool
+	// Metrics records kitchen load and queue depth as they change. Nil
+	// means metrics aren't tracked.
+	Metrics *metrics.Registry
+
+	mu         sync.RWMutex
+	wg         sync.WaitGroup
+	closed     bool
+	backorders []*restaurant.Order
+}
+
+// NewKitchen creates a new kitchen instance. clock drives all simulated
+// delays, so a VirtualClock makes prep time advance instantly while still
+// producing meaningful ReadyAt timestamps. stationWorkers sizes the worker
+// pool for each station, e.g. {StationGrill: 2, StationFryer: 1}.
+func NewKitchen(restaurantID int, mode restaurant.SimulationMode, clock restaurant.Clock, maxCapacity int, stationWorkers map[restaurant.Station]int) *Kitchen {
+	stations := make(map[restaurant.Station]*StationPool, len(stationWorkers))
+	for station, workers := range stationWorkers {
+		stations[station] = NewStationPool(station, workers, clock)
+	}
+
+	return &Kitchen{
+		RestaurantID: restaurantID,
+		Mode:         mode,
+		Clock:        clock,
+		MaxCapacity:  maxCapacity,
+		Stations:     stations,
+		OrderQueue:   make(chan *restaurant.Order, maxCapacity),
+		ReadyOrders:  make(chan *restaurant.Order, maxCapacity),
+	}
+}
+
+// ProcessOrders processes orders from OrderQueue until it is closed. Once
+// drained, it waits for any in-flight prepareOrder goroutines and station
+// pools to finish before closing ReadyOrders, so downstream consumers see
+// a clean shutdown.
+func (k *Kitchen) ProcessOrders() {
+	for order := range k.OrderQueue {
+		k.reportQueueDepth()
+
+		// Wait if kitchen is at capacity, bailing out early if the order
+		// is cancelled (or times out on customer patience) before a slot
+		// frees up.
+		cancelled := false
+		for {
+			if order.Ctx.Err() != nil {
+				cancelled = true
+				break
+			}
+			k.mu.Lock()
+			if k.CurrentLoad < k.MaxCapacity {
+				k.CurrentLoad++
+				k.mu.Unlock()
+				k.reportLoad()
+				break
+			}
+			k.mu.Unlock()
+			slog.Info("kitchen at capacity, order waiting", "restaurant_id", k.RestaurantID, "order_id", order.ID)
+			if err := restaurant.SimulateDelay(order.Ctx, k.Clock, 1*time.Second, "Kitchen capacity wait"); err != nil {
+				cancelled = true
+				break
+			}
+		}
+		if cancelled {
+			order.SetStatus(restaurant.OrderCancelled)
+			k.notify(order, restaurant.OrderCancelled)
+			slog.Info("kitchen: order cancelled while waiting for capacity", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+			continue
+		}
+
+		k.wg.Add(1)
+		go k.prepareOrder(order)
+	}
+
+	k.wg.Wait()
+	for _, pool := range k.Stations {
+		pool.Shutdown()
+	}
+	close(k.ReadyOrders)
+}
+
+// prepareOrder simulates preparing a single order. Each item is dispatched
+// to the station(s) it's tagged with; prepareOrder waits for every item to
+// finish before the order is marked ready. Items with no tagged station
+// are prepared inline.
+func (k *Kitchen) prepareOrder(order *restaurant.Order) {
+	defer k.wg.Done()
+
+	if order.Ctx.Err() != nil {
+		order.SetStatus(restaurant.OrderCancelled)
+		k.notify(order, restaurant.OrderCancelled)
+		slog.Info("kitchen: order cancelled before preparation started", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+		k.mu.Lock()
+		k.CurrentLoad--
+		k.mu.Unlock()
+		k.reportLoad()
+		return
+	}
+
+	if k.Inventory != nil {
+		if uses := aggregateIngredientUse(order.Items); len(uses) > 0 && !k.Inventory.TryReserve(uses) {
+			order.SetStatus(restaurant.OrderBackordered)
+			k.notify(order, restaurant.OrderBackordered)
+			slog.Info("kitchen: order backordered, insufficient ingredient stock", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+
+			// The order keeps its capacity slot and CurrentLoad stays
+			// unchanged: it's still occupying the kitchen, just waiting on
+			// ingredients rather than station time. RetryBackordered picks
+			// it back up once Inventory reports a restock.
+			k.mu.Lock()
+			k.backorders = append(k.backorders, order)
+			k.mu.Unlock()
+			return
+		}
+	}
+
+	k.cook(order)
+}
+
+// cook runs the station preparation for order, which must already hold a
+// capacity slot and have its ingredients reserved. It's shared by
+// prepareOrder, for freshly dispatched orders, and RetryBackordered, for
+// orders that were waiting on ingredient stock.
+func (k *Kitchen) cook(order *restaurant.Order) {
+	order.SetStatus(restaurant.OrderPreparing)
+	k.notify(order, restaurant.OrderPreparing)
+	slog.Info("kitchen: starting to prepare order", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+
+	var itemWG sync.WaitGroup
+	for _, item := range order.Items {
+		if order.Ctx.Err() != nil {
+			break
+		}
+
+		if len(item.Stations) == 0 {
+			restaurant.SimulateDelay(order.Ctx, k.Clock, item.PrepTime, fmt.Sprintf("Preparing %s for order #%d", item.Name, order.ID))
+			continue
+		}
+
+		for _, station := range item.Stations {
+			pool, ok := k.Stations[station]
+			if !ok {
+				slog.Info("kitchen: no station configured, preparing inline", "restaurant_id", k.RestaurantID, "station", station, "item", item.Name)
+				restaurant.SimulateDelay(order.Ctx, k.Clock, item.PrepTime, fmt.Sprintf("Preparing %s for order #%d", item.Name, order.ID))
+				continue
+			}
+
+			itemWG.Add(1)
+			pool.Submit(order, item, itemWG.Done)
+		}
+	}
+	itemWG.Wait()
+
+	k.mu.Lock()
+	k.CurrentLoad--
+	k.mu.Unlock()
+	k.reportLoad()
+
+	if order.Ctx.Err() != nil {
+		order.SetStatus(restaurant.OrderCancelled)
+		k.notify(order, restaurant.OrderCancelled)
+		slog.Info("kitchen: order cancelled during preparation", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+		return
+	}
+
+	order.MarkReady(k.Clock.Now())
+	k.notify(order, restaurant.OrderReady)
+
+	slog.Info("kitchen: order ready", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+
+	k.ReadyOrders <- order
+}
+
+// RetryBackordered re-attempts ingredient reservation for every order
+// currently waiting on stock, cooking the ones that now succeed and
+// leaving the rest backordered. It's meant to be wired up as
+// Inventory.OnRestock, so a restock immediately retries whatever it may
+// have just unblocked instead of leaving orders stuck until the next one.
+func (k *Kitchen) RetryBackordered() {
+	k.mu.Lock()
+	pending := k.backorders
+	k.backorders = nil
+	k.mu.Unlock()
+
+	var stillBackordered []*restaurant.Order
+	for _, order := range pending {
+		if order.Ctx.Err() != nil {
+			order.SetStatus(restaurant.OrderCancelled)
+			k.notify(order, restaurant.OrderCancelled)
+			slog.Info("kitchen: backordered order cancelled", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+			k.mu.Lock()
+			k.CurrentLoad--
+			k.mu.Unlock()
+			k.reportLoad()
+			continue
+		}
+
+		if uses := aggregateIngredientUse(order.Items); len(uses) > 0 && !k.Inventory.TryReserve(uses) {
+			stillBackordered = append(stillBackordered, order)
+			continue
+		}
+
+		k.mu.Lock()
+		if k.closed {
+			k.mu.Unlock()
+			stillBackordered = append(stillBackordered, order)
+			continue
+		}
+		k.wg.Add(1)
+		k.mu.Unlock()
+
+		slog.Info("kitchen: retrying backordered order", "restaurant_id", k.RestaurantID, "order_id", order.ID, "customer", order.CustomerName)
+		go func(order *restaurant.Order) {
+			defer k.wg.Done()
+			k.cook(order)
+		}(order)
+	}
+
+	if len(stillBackordered) > 0 {
+		k.mu.Lock()
+		k.backorders = append(k.backorders, stillBackordered...)
+		k.mu.Unlock()
+	}
+}
+
+func (k *Kitchen) notify(order *restaurant.Order, status restaurant.OrderStatus) {
+	if k.Notifier != nil {
+		k.Notifier.Publish(order, status)
+	}
+}
+
+// reportLoad pushes the kitchen's current load to Metrics, if configured.
+func (k *Kitchen) reportLoad() {
+	if k.Metrics == nil {
+		return
+	}
+	k.mu.RLock()
+	load := k.CurrentLoad
+	k.mu.RUnlock()
+	k.Metrics.SetKitchenLoad(load)
+}
+
+// reportQueueDepth pushes the number of orders waiting in OrderQueue to
+// Metrics, if configured.
+func (k *Kitchen) reportQueueDepth() {
+	if k.Metrics == nil {
+		return
+	}
+	k.Metrics.SetQueueDepth(len(k.OrderQueue))
+}
+
+// Shutdown closes OrderQueue, signalling ProcessOrders to drain in-flight
+// orders and stop. The caller must guarantee no further sends to OrderQueue
+// happen once Shutdown is called. Once closed, RetryBackordered stops
+// dispatching new cook goroutines, since ProcessOrders may already be
+// waiting for every in-flight one to finish.
+func (k *Kitchen) Shutdown() {
+	k.mu.Lock()
+	k.closed = true
+	k.mu.Unlock()
+	close(k.OrderQueue)
+}
+
+// Stats returns current kitchen load statistics.
+func (k *Kitchen) Stats() map[string]interface{} {
+	k.mu.RLock()
+	stats := map[string]interface{}{
+		"kitchen_load":     k.CurrentLoad,
+		"kitchen_capacity": k.MaxCapacity,
+	}
+	k.mu.RUnlock()
+
+	stationWorkers := make(map[restaurant.Station]int, len(k.Stations))
+	for station, pool := range k.Stations {
+		stationWorkers[station] = pool.Workers
+	}
+	stats["station_workers"] = stationWorkers
+
+	if k.Inventory != nil {
+		stats["inventory"] = k.Inventory.Levels()
+	}
+	return stats
+}
@@ -0,0 +1,219 @@
+package kitchen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// newTestKitchen wires up a Kitchen and the Restaurant that feeds it, the
+// same way main.go does.
+func newTestKitchen(t *testing.T, clock restaurant.Clock, maxCapacity int, stationWorkers map[restaurant.Station]int) (*Kitchen, *restaurant.Restaurant) {
+	t.Helper()
+	k := NewKitchen(1, restaurant.FastForwardMode, clock, maxCapacity, stationWorkers)
+	r := restaurant.NewRestaurant("Test Kitchen", 1, restaurant.FastForwardMode, k.OrderQueue, clock)
+	k.Notifier = r
+
+	go k.ProcessOrders()
+	t.Cleanup(func() {
+		k.Shutdown()
+		for range k.ReadyOrders {
+		}
+	})
+	return k, r
+}
+
+// newVirtualTestKitchen is newTestKitchen against a fresh VirtualClock, so
+// prep time advances instantly and tests stay fast and deterministic.
+func newVirtualTestKitchen(t *testing.T, maxCapacity int, stationWorkers map[restaurant.Station]int) (*Kitchen, *restaurant.Restaurant) {
+	t.Helper()
+	return newTestKitchen(t, restaurant.NewVirtualClock(time.Unix(0, 0)), maxCapacity, stationWorkers)
+}
+
+func waitForStatus(t *testing.T, order *restaurant.Order, want restaurant.OrderStatus) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if order.Status() == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("order #%d status = %q, want %q", order.ID, order.Status(), want)
+}
+
+func TestKitchenCooksOrderEndToEnd(t *testing.T) {
+	k, r := newVirtualTestKitchen(t, 5, nil)
+
+	item := restaurant.MenuItem{Name: "Tomato Soup", Price: 6.99, PrepTime: 3 * time.Minute}
+	order, err := r.CreateOrder(context.Background(), "Alice", []restaurant.MenuItem{item})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	select {
+	case ready := <-k.ReadyOrders:
+		if ready.ID != order.ID {
+			t.Fatalf("ReadyOrders delivered order #%d, want #%d", ready.ID, order.ID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for order to become ready")
+	}
+	waitForStatus(t, order, restaurant.OrderReady)
+}
+
+func TestKitchenBackordersOnInsufficientStock(t *testing.T) {
+	k, r := newVirtualTestKitchen(t, 5, nil)
+	k.Inventory = NewInventory(map[string]int{"dough": 0})
+
+	item := restaurant.MenuItem{
+		Name:        "Margherita Pizza",
+		PrepTime:    time.Minute,
+		Ingredients: []restaurant.IngredientUse{{Ingredient: "dough", Quantity: 1}},
+	}
+	order, err := r.CreateOrder(context.Background(), "Bob", []restaurant.MenuItem{item})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	waitForStatus(t, order, restaurant.OrderBackordered)
+
+	k.mu.RLock()
+	load := k.CurrentLoad
+	k.mu.RUnlock()
+	if load != 1 {
+		t.Fatalf("CurrentLoad = %d, want 1 (backordered order should keep its capacity slot)", load)
+	}
+}
+
+func TestKitchenRetriesBackorderedOrderOnRestock(t *testing.T) {
+	k, r := newVirtualTestKitchen(t, 5, nil)
+	k.Inventory = NewInventory(map[string]int{"dough": 0})
+	k.Inventory.OnRestock = k.RetryBackordered
+
+	item := restaurant.MenuItem{
+		Name:        "Margherita Pizza",
+		PrepTime:    time.Minute,
+		Ingredients: []restaurant.IngredientUse{{Ingredient: "dough", Quantity: 1}},
+	}
+	order, err := r.CreateOrder(context.Background(), "Bob", []restaurant.MenuItem{item})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	waitForStatus(t, order, restaurant.OrderBackordered)
+
+	k.Inventory.Restock("dough", 1)
+
+	select {
+	case ready := <-k.ReadyOrders:
+		if ready.ID != order.ID {
+			t.Fatalf("ReadyOrders delivered order #%d, want #%d", ready.ID, order.ID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for backordered order to be retried and become ready")
+	}
+	waitForStatus(t, order, restaurant.OrderReady)
+}
+
+func TestKitchenCancelsBackorderedOrderOnRetry(t *testing.T) {
+	k, r := newVirtualTestKitchen(t, 5, nil)
+	k.Inventory = NewInventory(map[string]int{"dough": 0})
+
+	item := restaurant.MenuItem{
+		Name:        "Margherita Pizza",
+		PrepTime:    time.Minute,
+		Ingredients: []restaurant.IngredientUse{{Ingredient: "dough", Quantity: 1}},
+	}
+	order, err := r.CreateOrder(context.Background(), "Bob", []restaurant.MenuItem{item})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	waitForStatus(t, order, restaurant.OrderBackordered)
+
+	if err := r.CancelOrder(order.ID); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	// Nothing re-scans the backorder list except a retry, so the order
+	// stays Backordered until one happens - same as a real restock tick.
+	k.RetryBackordered()
+
+	waitForStatus(t, order, restaurant.OrderCancelled)
+
+	k.mu.RLock()
+	load := k.CurrentLoad
+	k.mu.RUnlock()
+	if load != 0 {
+		t.Fatalf("CurrentLoad = %d, want 0 after a backordered order is cancelled", load)
+	}
+}
+
+// TestKitchenCancelsOrderWhilePreparingAtStation exercises the cook/
+// StationPool.worker path specifically, as distinct from cancelling a
+// still-backordered order: it waits for the order to actually reach
+// OrderPreparing before cancelling, so the cancellation lands while a
+// station worker is mid-SimulateDelay. A RealClock is needed here since a
+// VirtualClock's Sleep advances time instantly, leaving no real window to
+// observe OrderPreparing before the item would finish on its own.
+func TestKitchenCancelsOrderWhilePreparingAtStation(t *testing.T) {
+	clock := restaurant.RealClock{}
+	k, r := newTestKitchen(t, clock, 5, map[restaurant.Station]int{restaurant.StationGrill: 1})
+
+	item := restaurant.MenuItem{
+		Name:     "Classic Burger",
+		PrepTime: 300 * time.Millisecond,
+		Stations: []restaurant.Station{restaurant.StationGrill},
+	}
+	order, err := r.CreateOrder(context.Background(), "Dana", []restaurant.MenuItem{item})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	waitForStatus(t, order, restaurant.OrderPreparing)
+
+	if err := r.CancelOrder(order.ID); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	waitForStatus(t, order, restaurant.OrderCancelled)
+
+	k.mu.RLock()
+	load := k.CurrentLoad
+	k.mu.RUnlock()
+	if load != 0 {
+		t.Fatalf("CurrentLoad = %d, want 0 after cancelling an order mid-preparation", load)
+	}
+
+	select {
+	case ready := <-k.ReadyOrders:
+		t.Fatalf("ReadyOrders delivered order #%d after it was cancelled mid-preparation", ready.ID)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestStationPoolLimitsConcurrentWorkers(t *testing.T) {
+	clock := restaurant.RealClock{}
+	const prep = 30 * time.Millisecond
+
+	pool := NewStationPool(restaurant.StationGrill, 1, clock)
+	defer pool.Shutdown()
+
+	item := restaurant.MenuItem{Name: "Burger", PrepTime: prep, Stations: []restaurant.Station{restaurant.StationGrill}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	order := &restaurant.Order{Ctx: ctx}
+
+	start := time.Now()
+	done := make(chan struct{}, 2)
+	pool.Submit(order, item, func() { done <- struct{}{} })
+	pool.Submit(order, item, func() { done <- struct{}{} })
+	<-done
+	<-done
+	elapsed := time.Since(start)
+
+	if elapsed < 2*prep {
+		t.Fatalf("two tasks on a single-worker station pool finished in %v, want at least %v (serialized)", elapsed, 2*prep)
+	}
+}
@@ -0,0 +1,68 @@
+package kitchen
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// stationTask is a single menu item waiting to be prepared at a station.
+// done is called once the item is finished, letting the caller wait for
+// every item in an order via a sync.WaitGroup.
+type stationTask struct {
+	order *restaurant.Order
+	item  restaurant.MenuItem
+	done  func()
+}
+
+// StationPool is a fixed-size worker pool for one kitchen station (grill,
+// fryer, salad, oven, ...). Each worker pulls items tagged for that
+// station and prepares them one at a time, so a rush on one station
+// doesn't block the others.
+type StationPool struct {
+	Station restaurant.Station
+	Workers int
+
+	clock restaurant.Clock
+	tasks chan stationTask
+	wg    sync.WaitGroup
+}
+
+// NewStationPool creates a StationPool with the given number of workers
+// and starts them.
+func NewStationPool(station restaurant.Station, workers int, clock restaurant.Clock) *StationPool {
+	p := &StationPool{
+		Station: station,
+		Workers: workers,
+		clock:   clock,
+		tasks:   make(chan stationTask, workers*4),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+func (p *StationPool) worker() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		restaurant.SimulateDelay(task.order.Ctx, p.clock, task.item.PrepTime, fmt.Sprintf("Preparing %s at %s station for order #%d", task.item.Name, p.Station, task.order.ID))
+		task.done()
+	}
+}
+
+// Submit enqueues item for preparation at this station on behalf of order.
+// done is called once the item is ready.
+func (p *StationPool) Submit(order *restaurant.Order, item restaurant.MenuItem, done func()) {
+	p.tasks <- stationTask{order: order, item: item, done: done}
+}
+
+// Shutdown closes the pool's task queue and waits for in-flight tasks to
+// finish. The caller must guarantee no further Submit calls happen once
+// Shutdown is called.
+func (p *StationPool) Shutdown() {
+	close(p.tasks)
+	p.wg.Wait()
+}
@@ -0,0 +1,224 @@
+// Package metrics exposes the restaurant simulation's Prometheus metrics
+// and backs the HTTP stats endpoints, so the two views of the same running
+// simulation never drift apart.
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry collects every metric for one running restaurant simulation.
+// Each restaurant process owns its own Registry, backed by its own
+// prometheus.Registry rather than the global DefaultRegisterer, so
+// multiple simulations can run in the same binary without colliding.
+//
+// Alongside the Prometheus collectors, Registry keeps a mutex-protected
+// snapshot of the same values so Snapshot can report them as a plain map,
+// for GetRestaurantStats and Kitchen.Stats to serve over HTTP without
+// every caller needing to understand the client_golang API.
+type Registry struct {
+	reg *prometheus.Registry
+
+	ordersCreated   prometheus.Counter
+	ordersCancelled *prometheus.CounterVec
+	kitchenLoad     prometheus.Gauge
+	queueDepth      prometheus.Gauge
+	inventoryLevel  *prometheus.GaugeVec
+	prepTime        prometheus.Histogram
+	deliveryTime    prometheus.Histogram
+	turnaround      prometheus.Histogram
+
+	mu                sync.Mutex
+	ordersCreatedN    int64
+	cancelledByReason map[string]int64
+	kitchenLoadN      int
+	queueDepthN       int
+	inventoryLevels   map[string]int
+	prepStat          durationStat
+	deliveryStat      durationStat
+	turnaroundStat    durationStat
+}
+
+// durationStat tracks the count and sum of a histogram's observations, so
+// Snapshot can report an average without reaching into Prometheus
+// internals.
+type durationStat struct {
+	count int64
+	sum   time.Duration
+}
+
+func (d *durationStat) observe(v time.Duration) {
+	d.count++
+	d.sum += v
+}
+
+func (d durationStat) average() time.Duration {
+	if d.count == 0 {
+		return 0
+	}
+	return d.sum / time.Duration(d.count)
+}
+
+// New creates a Registry with every simulation metric registered.
+func New() *Registry {
+	reg := prometheus.NewRegistry()
+	factory := promauto.With(reg)
+
+	return &Registry{
+		reg: reg,
+		ordersCreated: factory.NewCounter(prometheus.CounterOpts{
+			Name: "orders_created_total",
+			Help: "Total number of orders created.",
+		}),
+		ordersCancelled: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "orders_cancelled_total",
+			Help: "Total number of orders cancelled, by reason.",
+		}, []string{"reason"}),
+		kitchenLoad: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "kitchen_load",
+			Help: "Number of orders currently occupying a kitchen capacity slot.",
+		}),
+		queueDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "queue_depth",
+			Help: "Number of orders waiting in the kitchen's intake queue.",
+		}),
+		inventoryLevel: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "inventory_level",
+			Help: "Current ingredient stock level, by ingredient.",
+		}, []string{"ingredient"}),
+		prepTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "order_prep_duration_seconds",
+			Help:    "Time from order creation to the kitchen marking it ready.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		deliveryTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "order_delivery_duration_seconds",
+			Help:    "Time from an order being ready to it being delivered.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		turnaround: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "order_turnaround_duration_seconds",
+			Help:    "End-to-end time from order creation to delivery.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		cancelledByReason: make(map[string]int64),
+		inventoryLevels:   make(map[string]int),
+	}
+}
+
+// Handler serves the registry's metrics in the Prometheus exposition
+// format, for mounting at /metrics.
+func (m *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{})
+}
+
+// OrderCreated records a newly created order.
+func (m *Registry) OrderCreated() {
+	m.ordersCreated.Inc()
+
+	m.mu.Lock()
+	m.ordersCreatedN++
+	m.mu.Unlock()
+}
+
+// OrderCancelled records an order cancellation, labelled with why it was
+// cancelled (e.g. "customer_cancelled" or "patience_timeout").
+func (m *Registry) OrderCancelled(reason string) {
+	m.ordersCancelled.WithLabelValues(reason).Inc()
+
+	m.mu.Lock()
+	m.cancelledByReason[reason]++
+	m.mu.Unlock()
+}
+
+// SetKitchenLoad records how many orders currently occupy a kitchen
+// capacity slot.
+func (m *Registry) SetKitchenLoad(n int) {
+	m.kitchenLoad.Set(float64(n))
+
+	m.mu.Lock()
+	m.kitchenLoadN = n
+	m.mu.Unlock()
+}
+
+// SetQueueDepth records how many orders are waiting in the kitchen's
+// intake queue.
+func (m *Registry) SetQueueDepth(n int) {
+	m.queueDepth.Set(float64(n))
+
+	m.mu.Lock()
+	m.queueDepthN = n
+	m.mu.Unlock()
+}
+
+// SetInventoryLevel records the current stock level for ingredient.
+func (m *Registry) SetInventoryLevel(ingredient string, level int) {
+	m.inventoryLevel.WithLabelValues(ingredient).Set(float64(level))
+
+	m.mu.Lock()
+	m.inventoryLevels[ingredient] = level
+	m.mu.Unlock()
+}
+
+// ObservePrepTime records how long an order took to go from creation to
+// ready.
+func (m *Registry) ObservePrepTime(d time.Duration) {
+	m.prepTime.Observe(d.Seconds())
+
+	m.mu.Lock()
+	m.prepStat.observe(d)
+	m.mu.Unlock()
+}
+
+// ObserveDeliveryTime records how long an order took to go from ready to
+// delivered.
+func (m *Registry) ObserveDeliveryTime(d time.Duration) {
+	m.deliveryTime.Observe(d.Seconds())
+
+	m.mu.Lock()
+	m.deliveryStat.observe(d)
+	m.mu.Unlock()
+}
+
+// ObserveTurnaround records an order's end-to-end time, from creation to
+// delivered.
+func (m *Registry) ObserveTurnaround(d time.Duration) {
+	m.turnaround.Observe(d.Seconds())
+
+	m.mu.Lock()
+	m.turnaroundStat.observe(d)
+	m.mu.Unlock()
+}
+
+// Snapshot returns the registry's current values as a plain map, so HTTP
+// stats endpoints report the same numbers a Prometheus scrape would.
+func (m *Registry) Snapshot() map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cancelled := make(map[string]int64, len(m.cancelledByReason))
+	for reason, n := range m.cancelledByReason {
+		cancelled[reason] = n
+	}
+	inventory := make(map[string]int, len(m.inventoryLevels))
+	for ingredient, level := range m.inventoryLevels {
+		inventory[ingredient] = level
+	}
+
+	return map[string]interface{}{
+		"orders_created_total":   m.ordersCreatedN,
+		"orders_cancelled_total": cancelled,
+		"kitchen_load":           m.kitchenLoadN,
+		"queue_depth":            m.queueDepthN,
+		"inventory_level":        inventory,
+		"avg_prep_time":          m.prepStat.average().String(),
+		"avg_delivery_time":      m.deliveryStat.average().String(),
+		"avg_turnaround_time":    m.turnaroundStat.average().String(),
+	}
+}
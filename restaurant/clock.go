@@ -0,0 +1,49 @@
+package restaurant
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so the simulation can run against the wall clock or
+// a virtual clock that fast-forwards deterministically.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// RealClock is a Clock backed by the wall clock.
+type RealClock struct{}
+
+// Now returns the current wall-clock time.
+func (RealClock) Now() time.Time { return time.Now() }
+
+// Sleep blocks for d.
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// VirtualClock is a Clock that never actually waits: Sleep advances an
+// internal counter instead of blocking, so a fast-forward run completes
+// instantly while order timestamps still reflect simulated elapsed time.
+type VirtualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewVirtualClock creates a VirtualClock starting at epoch.
+func NewVirtualClock(epoch time.Time) *VirtualClock {
+	return &VirtualClock{now: epoch}
+}
+
+// Now returns the clock's current simulated time.
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep advances the simulated time by d without blocking.
+func (c *VirtualClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
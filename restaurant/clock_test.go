@@ -0,0 +1,130 @@
+package restaurant
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestVirtualClockAdvancesBySleptDuration(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	clock := NewVirtualClock(epoch)
+
+	clock.Sleep(5 * time.Minute)
+	clock.Sleep(30 * time.Second)
+
+	want := epoch.Add(5*time.Minute + 30*time.Second)
+	if got := clock.Now(); !got.Equal(want) {
+		t.Fatalf("Now() = %v, want %v", got, want)
+	}
+}
+
+// TestVirtualClockDeterministicAcrossRuns is the property two fast-forward
+// runs rely on: the same sequence of Sleep calls against a fresh
+// VirtualClock always produces the same Now() sequence, regardless of when
+// it's run in wall-clock time.
+func TestVirtualClockDeterministicAcrossRuns(t *testing.T) {
+	run := func() []time.Time {
+		clock := NewVirtualClock(time.Unix(0, 0))
+		var got []time.Time
+		for _, d := range []time.Duration{time.Second, 2 * time.Second, 90 * time.Millisecond} {
+			clock.Sleep(d)
+			got = append(got, clock.Now())
+		}
+		return got
+	}
+
+	first := run()
+	time.Sleep(10 * time.Millisecond)
+	second := run()
+
+	for i := range first {
+		if !first[i].Equal(second[i]) {
+			t.Fatalf("run mismatch at step %d: %v != %v", i, first[i], second[i])
+		}
+	}
+}
+
+// TestVirtualClockSleepIsConcurrencySafe checks that concurrent Sleep
+// calls don't race or drop updates, since Kitchen dispatches station work
+// and order prep against the same clock from many goroutines at once.
+func TestVirtualClockSleepIsConcurrencySafe(t *testing.T) {
+	clock := NewVirtualClock(time.Unix(0, 0))
+
+	const n = 200
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			clock.Sleep(time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	want := time.Unix(0, 0).Add(n * time.Millisecond)
+	if got := clock.Now(); !got.Equal(want) {
+		t.Fatalf("Now() = %v, want %v", got, want)
+	}
+}
+
+func TestSimulateDelayVirtualClockReturnsImmediately(t *testing.T) {
+	clock := NewVirtualClock(time.Unix(0, 0))
+
+	start := time.Now()
+	if err := SimulateDelay(context.Background(), clock, time.Hour, "test"); err != nil {
+		t.Fatalf("SimulateDelay returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("SimulateDelay against a VirtualClock took %v, want near-instant", elapsed)
+	}
+
+	want := time.Unix(0, 0).Add(time.Hour)
+	if got := clock.Now(); !got.Equal(want) {
+		t.Fatalf("clock.Now() = %v, want %v", got, want)
+	}
+}
+
+func TestSimulateDelayRealClockReturnsCtxErrOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := SimulateDelay(ctx, RealClock{}, time.Hour, "test")
+	elapsed := time.Since(start)
+
+	if err != context.Canceled {
+		t.Fatalf("SimulateDelay returned %v, want context.Canceled", err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("SimulateDelay took %v to observe cancellation, want well under the 1h delay", elapsed)
+	}
+}
+
+// TestSimulateDelayRealClockDoesNotLeakGoroutineOnCancel guards against a
+// background goroutine outliving a cancelled delay: before the fix, a
+// cancelled RealClock delay left a goroutine parked asleep for the rest of
+// the original duration instead of tearing down immediately.
+func TestSimulateDelayRealClockDoesNotLeakGoroutineOnCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := SimulateDelay(ctx, RealClock{}, time.Hour, "test"); err != context.Canceled {
+		t.Fatalf("SimulateDelay returned %v, want context.Canceled", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		runtime.Gosched()
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+	}
+	t.Fatalf("goroutine count did not settle back to %d after cancellation (now %d)", before, runtime.NumGoroutine())
+}
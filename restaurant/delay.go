@@ -0,0 +1,33 @@
+package restaurant
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SimulateDelay advances clock by duration. Against a VirtualClock this
+// returns immediately (after logging what it stood in for); against a
+// RealClock it actually blocks, but via a stoppable time.Timer rather than
+// a background goroutine, so cancelling ctx tears the wait down right
+// away instead of leaving a goroutine parked for the rest of duration. It
+// returns ctx.Err() if ctx is cancelled before the delay finishes, so
+// callers watching an order's Ctx can abort in-flight work promptly
+// instead of sleeping out a cancelled order.
+func SimulateDelay(ctx context.Context, clock Clock, duration time.Duration, description string) error {
+	if _, ok := clock.(*VirtualClock); ok {
+		slog.Debug("simulated delay", "description", description, "would_take", duration)
+		clock.Sleep(duration)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,420 @@
+// Package restaurant holds the core domain types for the simulation: menu
+// items, orders, and the Restaurant aggregate that tracks them.
+package restaurant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ogradyo/restaurant-simulator/metrics"
+)
+
+// OrderStatus represents the current status of an order
+type OrderStatus string
+
+const (
+	OrderReceived    OrderStatus = "received"
+	OrderPreparing   OrderStatus = "preparing"
+	OrderReady       OrderStatus = "ready"
+	OrderDelivered   OrderStatus = "delivered"
+	OrderCancelled   OrderStatus = "cancelled"
+	OrderBackordered OrderStatus = "backordered"
+)
+
+// SimulationMode represents the type of simulation
+type SimulationMode string
+
+const (
+	RealTimeMode    SimulationMode = "realtime"
+	FastForwardMode SimulationMode = "fastforward"
+)
+
+// IngredientUse records how much of an ingredient a MenuItem consumes from
+// kitchen inventory when it's prepared.
+type IngredientUse struct {
+	Ingredient string
+	Quantity   int
+}
+
+// MenuItem represents a single item in an order
+type MenuItem struct {
+	Name        string
+	Price       float64
+	PrepTime    time.Duration
+	Ingredients []IngredientUse
+	// Stations lists the kitchen station(s) that must prepare this item.
+	// An item with no stations is prepared inline, without going through a
+	// station worker pool.
+	Stations []Station
+}
+
+// Order represents a restaurant order. Status, ReadyAt, and DeliveredAt are
+// mutated by whichever goroutine currently owns the order (the kitchen,
+// then delivery) while it's concurrently readable from other goroutines
+// via Restaurant.GetOrderStatus, the SSE stream, and Publish - so every
+// access to those three fields must go through the methods below rather
+// than touching them directly; mu guards exactly those fields.
+type Order struct {
+	ID           int
+	CustomerName string
+	Items        []MenuItem
+	TotalAmount  float64
+
+	// Ctx is cancelled when the order is cancelled, either explicitly via
+	// Restaurant.CancelOrder or automatically once it has waited longer
+	// than Restaurant.Patience. The kitchen and delivery loops watch it
+	// via SimulateDelay so they can abort in-flight work promptly.
+	Ctx    context.Context `json:"-"`
+	cancel context.CancelFunc
+
+	mu          sync.Mutex
+	status      OrderStatus
+	createdAt   time.Time
+	readyAt     time.Time
+	deliveredAt time.Time
+}
+
+// newOrder creates an Order in OrderReceived status, stamped with
+// createdAt.
+func newOrder(id int, customerName string, items []MenuItem, totalAmount float64, createdAt time.Time, ctx context.Context, cancel context.CancelFunc) *Order {
+	return &Order{
+		ID:           id,
+		CustomerName: customerName,
+		Items:        items,
+		TotalAmount:  totalAmount,
+		Ctx:          ctx,
+		cancel:       cancel,
+		status:       OrderReceived,
+		createdAt:    createdAt,
+	}
+}
+
+// Status returns the order's current status.
+func (o *Order) Status() OrderStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.status
+}
+
+// CreatedAt returns when the order was created.
+func (o *Order) CreatedAt() time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.createdAt
+}
+
+// ReadyAt returns when the kitchen marked the order ready, or the zero
+// time if it isn't ready yet.
+func (o *Order) ReadyAt() time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.readyAt
+}
+
+// DeliveredAt returns when the order was delivered, or the zero time if
+// it hasn't been delivered yet.
+func (o *Order) DeliveredAt() time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.deliveredAt
+}
+
+// SetStatus transitions the order to status. Use MarkReady/MarkDelivered
+// instead when a timestamp accompanies the transition, so the two fields
+// are updated atomically.
+func (o *Order) SetStatus(status OrderStatus) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.status = status
+}
+
+// MarkReady transitions the order to OrderReady and stamps ReadyAt.
+func (o *Order) MarkReady(at time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.status = OrderReady
+	o.readyAt = at
+}
+
+// MarkDelivered transitions the order to OrderDelivered and stamps
+// DeliveredAt.
+func (o *Order) MarkDelivered(at time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.status = OrderDelivered
+	o.deliveredAt = at
+}
+
+// snapshot is a point-in-time, lock-free copy of an Order's mutable
+// fields, safe to read or JSON-encode concurrently with further
+// transitions on the live Order.
+type snapshot struct {
+	ID           int
+	CustomerName string
+	Items        []MenuItem
+	TotalAmount  float64
+	Status       OrderStatus
+	CreatedAt    time.Time
+	ReadyAt      time.Time
+	DeliveredAt  time.Time
+}
+
+func (o *Order) snapshot() snapshot {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return snapshot{
+		ID:           o.ID,
+		CustomerName: o.CustomerName,
+		Items:        o.Items,
+		TotalAmount:  o.TotalAmount,
+		Status:       o.status,
+		CreatedAt:    o.createdAt,
+		ReadyAt:      o.readyAt,
+		DeliveredAt:  o.deliveredAt,
+	}
+}
+
+// MarshalJSON encodes the order's current state under lock, so HTTP
+// handlers and the SSE stream never race the kitchen or delivery
+// goroutines mutating it.
+func (o *Order) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.snapshot())
+}
+
+// OrderEvent is emitted whenever an order's status changes.
+type OrderEvent struct {
+	Order     *Order
+	Status    OrderStatus
+	Timestamp time.Time
+}
+
+// Restaurant represents the main restaurant system
+type Restaurant struct {
+	Name       string
+	ID         int
+	Mode       SimulationMode
+	Orders     map[int]*Order
+	OrderCount int
+	// Patience is how long an order may sit before it is automatically
+	// cancelled. Zero means orders never time out on their own.
+	Patience time.Duration
+	// Metrics records order lifecycle metrics as they happen via Publish.
+	// Nil means metrics aren't tracked.
+	Metrics *metrics.Registry
+
+	orderQueue chan<- *Order
+	clock      Clock
+
+	mu          sync.RWMutex
+	subscribers map[int]chan OrderEvent
+	nextSubID   int
+}
+
+// NewRestaurant creates a new restaurant instance. orderQueue is the
+// kitchen's intake channel; CreateOrder enqueues new orders onto it. clock
+// is used to stamp CreatedAt so fast-forward runs produce meaningful,
+// reproducible timestamps.
+func NewRestaurant(name string, id int, mode SimulationMode, orderQueue chan<- *Order, clock Clock) *Restaurant {
+	return &Restaurant{
+		Name:        name,
+		ID:          id,
+		Mode:        mode,
+		Orders:      make(map[int]*Order),
+		orderQueue:  orderQueue,
+		clock:       clock,
+		subscribers: make(map[int]chan OrderEvent),
+	}
+}
+
+// CreateOrder creates a new order and adds it to the kitchen queue.
+// admissionCtx governs only the enqueue attempt, not the order's own
+// lifecycle: if it's cancelled while CreateOrder is blocked handing the
+// order to a full kitchen queue, the attempt is abandoned instead of
+// risking a send on a queue the kitchen may by then have closed for
+// shutdown. Callers that don't have a shutdown signal to thread through
+// (e.g. a fixed background goroutine) can pass context.Background().
+func (r *Restaurant) CreateOrder(admissionCtx context.Context, customerName string, items []MenuItem) (*Order, error) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if r.Patience > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), r.Patience)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	r.mu.Lock()
+	r.OrderCount++
+	order := newOrder(r.OrderCount, customerName, items, calculateTotal(items), r.clock.Now(), ctx, cancel)
+	r.Orders[order.ID] = order
+	r.mu.Unlock()
+
+	select {
+	case r.orderQueue <- order:
+	case <-admissionCtx.Done():
+		r.mu.Lock()
+		delete(r.Orders, order.ID)
+		r.mu.Unlock()
+		cancel()
+		return nil, admissionCtx.Err()
+	}
+
+	slog.Info("order created", "restaurant_id", r.ID, "order_id", order.ID, "customer", customerName, "total", order.TotalAmount)
+	r.Publish(order, OrderReceived)
+	return order, nil
+}
+
+// calculateTotal calculates the total amount for menu items
+func calculateTotal(items []MenuItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.Price
+	}
+	return total
+}
+
+// GetOrderStatus returns the status of an order
+func (r *Restaurant) GetOrderStatus(orderID int) (*Order, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	order, exists := r.Orders[orderID]
+	return order, exists
+}
+
+// CancelOrder cancels order id, whether it is still queued, backordered,
+// being prepared, or awaiting delivery. The kitchen and delivery loops
+// observe the cancellation via Order.Ctx and transition the order to
+// OrderCancelled themselves, since they're the ones that know what
+// in-flight work to abort. It returns an error if the order doesn't exist
+// or has already reached a terminal status.
+func (r *Restaurant) CancelOrder(id int) error {
+	r.mu.Lock()
+	order, exists := r.Orders[id]
+	if !exists {
+		r.mu.Unlock()
+		return fmt.Errorf("order #%d not found", id)
+	}
+	switch order.Status() {
+	case OrderDelivered, OrderCancelled:
+		r.mu.Unlock()
+		return fmt.Errorf("order #%d is already %s and cannot be cancelled", id, order.Status())
+	}
+	r.mu.Unlock()
+
+	order.cancel()
+	return nil
+}
+
+// GetRestaurantStats returns order-related restaurant statistics. Counters
+// and timing figures are read from Metrics, where configured, so this and
+// the /metrics Prometheus endpoint never disagree.
+func (r *Restaurant) GetRestaurantStats() map[string]interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := map[string]interface{}{
+		"total_orders": r.OrderCount,
+	}
+
+	statusCounts := make(map[OrderStatus]int)
+	for _, order := range r.Orders {
+		statusCounts[order.Status()]++
+	}
+	stats["orders_by_status"] = statusCounts
+
+	if r.Metrics != nil {
+		for k, v := range r.Metrics.Snapshot() {
+			stats[k] = v
+		}
+	}
+
+	return stats
+}
+
+// Subscribe registers a new listener for order status change events. The
+// returned cancel function must be called once the subscriber is done
+// listening, to release its channel.
+func (r *Restaurant) Subscribe() (<-chan OrderEvent, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextSubID
+	r.nextSubID++
+	ch := make(chan OrderEvent, 16)
+	r.subscribers[id] = ch
+
+	cancel := func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if sub, ok := r.subscribers[id]; ok {
+			delete(r.subscribers, id)
+			close(sub)
+		}
+	}
+	return ch, cancel
+}
+
+// Publish notifies all subscribers that order has transitioned to status.
+// status is passed explicitly by the caller, rather than read back off
+// order, because the caller just made (or observed) that exact transition
+// and order may already be owned by another goroutine moving it to its
+// next status by the time Publish runs - e.g. CreateOrder hands order to
+// the kitchen before publishing its "received" event. Recording the
+// caller's status keeps the event log and metrics deterministic for a
+// given seed instead of racing whichever transition happens to land
+// first. Subscribers that aren't keeping up are skipped rather than
+// blocking the simulation. Every order, from every package, transitions
+// through here, so it's also where lifecycle metrics and the structured
+// event log are recorded.
+func (r *Restaurant) Publish(order *Order, status OrderStatus) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event := OrderEvent{Order: order, Status: status, Timestamp: r.clock.Now()}
+	r.recordMetrics(order, status)
+	slog.Info("order event", "restaurant_id", r.ID, "order_id", order.ID, "status", status, "timestamp", event.Timestamp)
+
+	for _, sub := range r.subscribers {
+		select {
+		case sub <- event:
+		default:
+		}
+	}
+}
+
+// recordMetrics updates r.Metrics for the lifecycle transition order has
+// just made, using status rather than re-reading order.Status() so a
+// concurrent transition on order can't make this observe a different
+// status than the one just logged by Publish. It's a no-op if no Metrics
+// registry is configured.
+func (r *Restaurant) recordMetrics(order *Order, status OrderStatus) {
+	if r.Metrics == nil {
+		return
+	}
+
+	switch status {
+	case OrderReceived:
+		r.Metrics.OrderCreated()
+	case OrderCancelled:
+		r.Metrics.OrderCancelled(cancelReason(order.Ctx))
+	case OrderReady:
+		r.Metrics.ObservePrepTime(order.ReadyAt().Sub(order.CreatedAt()))
+	case OrderDelivered:
+		r.Metrics.ObserveDeliveryTime(order.DeliveredAt().Sub(order.ReadyAt()))
+		r.Metrics.ObserveTurnaround(order.DeliveredAt().Sub(order.CreatedAt()))
+	}
+}
+
+// cancelReason reports why a cancelled order's context was cancelled:
+// "patience_timeout" if it ran out the clock on Restaurant.Patience,
+// "customer_cancelled" otherwise.
+func cancelReason(ctx context.Context) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "patience_timeout"
+	}
+	return "customer_cancelled"
+}
@@ -0,0 +1,14 @@
+package restaurant
+
+// Station identifies a kitchen station that prepares certain menu items,
+// e.g. the grill or the fryer. Each station is staffed by its own worker
+// pool, so a pizza-heavy rush saturates the oven independently of whether
+// the salad station is busy.
+type Station string
+
+const (
+	StationGrill Station = "grill"
+	StationFryer Station = "fryer"
+	StationSalad Station = "salad"
+	StationOven  Station = "oven"
+)
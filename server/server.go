@@ -0,0 +1,230 @@
+// Package server exposes a running restaurant simulation over HTTP,
+// including an SSE stream of order status transitions.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ogradyo/restaurant-simulator/kitchen"
+	"github.com/ogradyo/restaurant-simulator/metrics"
+	"github.com/ogradyo/restaurant-simulator/restaurant"
+)
+
+// Server exposes a Restaurant simulation over HTTP.
+type Server struct {
+	Restaurant *restaurant.Restaurant
+	Kitchen    *kitchen.Kitchen
+	Menu       map[string]restaurant.MenuItem
+	Metrics    *metrics.Registry
+
+	httpServer *http.Server
+	// shutdownCtx is the ctx passed to Start, cancelled once shutdown
+	// begins. handleOrders uses it to abandon a CreateOrder call that's
+	// still blocked on a full kitchen queue when shutdown starts, rather
+	// than risk it sending to the queue after the kitchen closes it.
+	shutdownCtx context.Context
+}
+
+// New creates a Server that serves r and k on addr. reg backs /metrics
+// with a Prometheus scrape endpoint; it may be nil to disable it. Call
+// Start to begin serving.
+func New(addr string, r *restaurant.Restaurant, k *kitchen.Kitchen, menu map[string]restaurant.MenuItem, reg *metrics.Registry) *Server {
+	s := &Server{Restaurant: r, Kitchen: k, Menu: menu, Metrics: reg, shutdownCtx: context.Background()}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orders", s.handleOrders)
+	mux.HandleFunc("/orders/", s.handleOrderByID)
+	mux.HandleFunc("/stats", s.handleStats)
+	mux.HandleFunc("/restock", s.handleRestock)
+	if reg != nil {
+		mux.Handle("/metrics", reg.Handler())
+	}
+
+	s.httpServer = &http.Server{Addr: addr, Handler: mux}
+	return s
+}
+
+// Start serves HTTP requests until ctx is cancelled, then gracefully shuts
+// down, waiting for in-flight requests to finish before returning.
+func (s *Server) Start(ctx context.Context) error {
+	s.shutdownCtx = ctx
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		return <-errCh
+	case err := <-errCh:
+		return err
+	}
+}
+
+type createOrderRequest struct {
+	CustomerName string   `json:"customer_name"`
+	Items        []string `json:"items"`
+}
+
+func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req createOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.CustomerName == "" || len(req.Items) == 0 {
+		http.Error(w, "customer_name and items are required", http.StatusBadRequest)
+		return
+	}
+
+	items := make([]restaurant.MenuItem, 0, len(req.Items))
+	for _, name := range req.Items {
+		item, ok := s.Menu[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown menu item %q", name), http.StatusBadRequest)
+			return
+		}
+		items = append(items, item)
+	}
+
+	order, err := s.Restaurant.CreateOrder(s.shutdownCtx, req.CustomerName, items)
+	if err != nil {
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, http.StatusCreated, order)
+}
+
+// handleOrderByID serves GET /orders/{id}, which returns the order's current
+// state, and DELETE /orders/{id}, which cancels it. Cancellation only
+// requests that in-flight work stop; the order's status transitions to
+// "cancelled" asynchronously once the kitchen or delivery loop observes it.
+func (s *Server) handleOrderByID(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/orders/")
+	if id == "stream" {
+		s.handleOrderStream(w, r)
+		return
+	}
+
+	orderID, err := strconv.Atoi(id)
+	if err != nil {
+		http.Error(w, "invalid order id", http.StatusBadRequest)
+		return
+	}
+
+	order, ok := s.Restaurant.GetOrderStatus(orderID)
+	if !ok {
+		http.Error(w, "order not found", http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := s.Restaurant.CancelOrder(orderID); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeJSON(w, http.StatusOK, order)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, order)
+}
+
+// handleOrderStream serves GET /orders/stream, an SSE feed of OrderEvents.
+func (s *Server) handleOrderStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel := s.Restaurant.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
+
+type restockRequest struct {
+	Ingredient string `json:"ingredient"`
+	Amount     int    `json:"amount"`
+}
+
+func (s *Server) handleRestock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Kitchen.Inventory == nil {
+		http.Error(w, "inventory is not configured for this kitchen", http.StatusConflict)
+		return
+	}
+
+	var req restockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Ingredient == "" || req.Amount <= 0 {
+		http.Error(w, "ingredient and a positive amount are required", http.StatusBadRequest)
+		return
+	}
+
+	s.Kitchen.Inventory.Restock(req.Ingredient, req.Amount)
+	writeJSON(w, http.StatusOK, s.Kitchen.Inventory.Levels())
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	stats := s.Restaurant.GetRestaurantStats()
+	for k, v := range s.Kitchen.Stats() {
+		stats[k] = v
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}